@@ -0,0 +1,397 @@
+/*
+Package v1 contains API Schema definitions for the metrics v1 API group.
+*/
+// +kubebuilder:object:generate=true
+// +groupName=metrics.dora-operator.io
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the API Group Version used to register these objects.
+var GroupVersion = schema.GroupVersion{Group: "metrics.dora-operator.io", Version: "v1"}
+
+// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+var SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+// AddToScheme adds the types in this group-version to the given scheme.
+var AddToScheme = SchemeBuilder.AddToScheme
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&DORAMetric{},
+		&DORAMetricList{},
+	)
+	metav1.AddToGroupVersion(scheme, GroupVersion)
+	return nil
+}
+
+// SourceConfig describes where a metric's raw data comes from and how to
+// authenticate against it. Exactly one provider-specific selector should be
+// set for a given Provider; the others are ignored.
+type SourceConfig struct {
+	// Provider is the name of the registered provider to use, e.g.
+	// "github", "gitlab", "jenkins", "argocd", "pagerduty" or "prometheus".
+	Provider string `json:"provider"`
+
+	// Repo selects a source repository in "owner/name" form, used by the
+	// github provider. The gitlab provider reads Project instead.
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// Project selects a Jenkins job path or GitLab project path.
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// Namespace scopes the argocd/kubernetes Deployment lookups.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Service is a PagerDuty service ID or the service label to match
+	// against Prometheus alerts.
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// AuthSecretRef points to a Secret in the DORAMetric's namespace
+	// holding provider credentials (e.g. a "token" key).
+	// +optional
+	AuthSecretRef *corev1LocalObjectReference `json:"authSecretRef,omitempty"`
+
+	// Endpoint overrides the provider's default API base URL, used for
+	// self-hosted GitLab/Jenkins/Prometheus instances.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Window is how far back a collection looks, as a Go duration string,
+	// e.g. "24h" or "168h". Day units such as "7d" are not accepted.
+	// +kubebuilder:default="24h"
+	Window string `json:"window,omitempty"`
+}
+
+// corev1LocalObjectReference mirrors corev1.LocalObjectReference; kept as a
+// local alias so this file has no dependency on k8s.io/api/core/v1 beyond
+// what controller-gen needs for the CRD schema.
+type corev1LocalObjectReference struct {
+	Name string `json:"name"`
+}
+
+// MetricConfig configures the collection of a single DORA metric.
+type MetricConfig struct {
+	// Name is one of "deployment-frequency", "lead-time", "mttr" or
+	// "change-failure-rate".
+	Name string `json:"name"`
+
+	// Enabled toggles collection of this metric on or off.
+	Enabled bool `json:"enabled"`
+
+	// Schedule is a standard cron expression controlling how often this
+	// metric is recomputed.
+	Schedule string `json:"schedule"`
+
+	// Source configures the provider this metric is collected from.
+	// +optional
+	Source SourceConfig `json:"source,omitempty"`
+}
+
+// PrometheusExportConfig controls exporting metrics via the manager's
+// Prometheus metrics endpoint.
+type PrometheusExportConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// WebhookSink is one outbound destination for collected metric values.
+type WebhookSink struct {
+	// Name identifies this sink in delivery status conditions and metrics.
+	Name string `json:"name"`
+
+	// Type selects the payload shape. One of "generic" (a signed CloudEvents
+	// 1.0 JSON envelope), "slack" (a Slack incoming-webhook message) or
+	// "pagerduty" (a PagerDuty Events v2 payload).
+	// +kubebuilder:validation:Enum=generic;slack;pagerduty
+	// +kubebuilder:default=generic
+	Type string `json:"type,omitempty"`
+
+	// URL is the destination to POST to.
+	URL string `json:"url"`
+
+	// SecretRef points to a Secret in the DORAMetric's namespace whose
+	// "secret" key holds the HMAC signing key for "generic" sinks or the
+	// Events v2 routing key for "pagerduty" sinks. "slack" sinks ignore it;
+	// a "generic" sink without one sends its body unsigned.
+	// +optional
+	SecretRef *corev1LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Filter is a small boolean expression like "mttr > 60" gating whether
+	// an event is sent to this sink. A metric whose name doesn't appear in
+	// the expression always passes. Empty means always send.
+	// +optional
+	Filter string `json:"filter,omitempty"`
+}
+
+// WebhookExportConfig controls exporting metrics via outbound webhooks.
+type WebhookExportConfig struct {
+	Enabled bool          `json:"enabled"`
+	Sinks   []WebhookSink `json:"sinks,omitempty"`
+}
+
+// OTLPExportConfig controls exporting metrics as OTLP gauges to an
+// OpenTelemetry collector, for teams unifying DORA metrics with an
+// existing OTel pipeline instead of scraping Prometheus.
+type OTLPExportConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Endpoint is the collector's host:port. Required when Enabled.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Protocol selects the OTLP transport: "grpc" or "http".
+	// +kubebuilder:validation:Enum=grpc;http
+	// +kubebuilder:default=grpc
+	Protocol string `json:"protocol,omitempty"`
+
+	// Insecure disables TLS, for collectors running as a local sidecar.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// TLSSecretRef points to a Secret in the DORAMetric's namespace holding
+	// mTLS client material under "tls.crt", "tls.key" and "ca.crt". Ignored
+	// when Insecure is set.
+	// +optional
+	TLSSecretRef *corev1LocalObjectReference `json:"tlsSecretRef,omitempty"`
+
+	// BearerTokenSecretRef points to a Secret in the DORAMetric's namespace
+	// holding a bearer token under its "token" key, sent as the
+	// Authorization header on every export. It may be combined with mTLS
+	// client auth via TLSSecretRef.
+	// +optional
+	BearerTokenSecretRef *corev1LocalObjectReference `json:"bearerTokenSecretRef,omitempty"`
+}
+
+// ExportConfig groups all of the ways a DORAMetric's results can be shipped
+// out of the operator.
+type ExportConfig struct {
+	// +optional
+	Prometheus PrometheusExportConfig `json:"prometheus,omitempty"`
+	// +optional
+	Webhook WebhookExportConfig `json:"webhook,omitempty"`
+	// +optional
+	OTLP OTLPExportConfig `json:"otlp,omitempty"`
+}
+
+// DORAMetricSpec defines the desired state of a DORAMetric.
+type DORAMetricSpec struct {
+	// Team labels the team that owns the service these metrics describe.
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Service labels the service these metrics describe.
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// Environment labels the environment these metrics describe, e.g.
+	// "production" or "staging".
+	// +optional
+	Environment string `json:"environment,omitempty"`
+
+	// Metrics lists the individual DORA metrics to collect.
+	Metrics []MetricConfig `json:"metrics"`
+
+	// Export configures where collected metric values are sent.
+	// +optional
+	Export ExportConfig `json:"export,omitempty"`
+}
+
+// MetricStatus records the last collection result for a single metric.
+type MetricStatus struct {
+	Value     float64 `json:"value,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	Timestamp string  `json:"timestamp,omitempty"`
+}
+
+// DORAMetricStatus defines the observed state of a DORAMetric.
+type DORAMetricStatus struct {
+	// Metrics maps a metric name to its last collection result.
+	// +optional
+	Metrics map[string]MetricStatus `json:"metrics,omitempty"`
+
+	// LastCollection is the timestamp of the most recent successful
+	// collection of any of this DORAMetric's metrics.
+	// +optional
+	LastCollection string `json:"lastCollection,omitempty"`
+
+	// Conditions records the outcome of the most recent delivery attempt to
+	// each webhook sink, keyed by a condition type of "WebhookDelivery:<sink
+	// name>" so multiple sinks don't overwrite each other's status.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Team",type=string,JSONPath=`.spec.team`
+// +kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.spec.service`
+
+// DORAMetric is the Schema for the dorametrics API.
+type DORAMetric struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DORAMetricSpec   `json:"spec,omitempty"`
+	Status DORAMetricStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DORAMetricList contains a list of DORAMetric.
+type DORAMetricList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DORAMetric `json:"items"`
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *corev1LocalObjectReference) DeepCopy() *corev1LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(corev1LocalObjectReference)
+	*out = *in
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
+	*out = *in
+	out.AuthSecretRef = in.AuthSecretRef.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *MetricConfig) DeepCopyInto(out *MetricConfig) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *WebhookSink) DeepCopyInto(out *WebhookSink) {
+	*out = *in
+	out.SecretRef = in.SecretRef.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *WebhookExportConfig) DeepCopyInto(out *WebhookExportConfig) {
+	*out = *in
+	if in.Sinks != nil {
+		out.Sinks = make([]WebhookSink, len(in.Sinks))
+		for i := range in.Sinks {
+			in.Sinks[i].DeepCopyInto(&out.Sinks[i])
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *OTLPExportConfig) DeepCopyInto(out *OTLPExportConfig) {
+	*out = *in
+	out.TLSSecretRef = in.TLSSecretRef.DeepCopy()
+	out.BearerTokenSecretRef = in.BearerTokenSecretRef.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ExportConfig) DeepCopyInto(out *ExportConfig) {
+	*out = *in
+	in.Webhook.DeepCopyInto(&out.Webhook)
+	in.OTLP.DeepCopyInto(&out.OTLP)
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *DORAMetricSpec) DeepCopyInto(out *DORAMetricSpec) {
+	*out = *in
+	if in.Metrics != nil {
+		out.Metrics = make([]MetricConfig, len(in.Metrics))
+		for i := range in.Metrics {
+			in.Metrics[i].DeepCopyInto(&out.Metrics[i])
+		}
+	}
+	in.Export.DeepCopyInto(&out.Export)
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *DORAMetricStatus) DeepCopyInto(out *DORAMetricStatus) {
+	*out = *in
+	if in.Metrics != nil {
+		out.Metrics = make(map[string]MetricStatus, len(in.Metrics))
+		for k, v := range in.Metrics {
+			out.Metrics[k] = v
+		}
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out, including ObjectMeta (Labels,
+// Annotations, OwnerReferences, Finalizers, ManagedFields, ...) so the
+// result is safe for a caller to mutate without corrupting the object
+// controller-runtime's cache handed out.
+func (in *DORAMetric) DeepCopyInto(out *DORAMetric) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *DORAMetric) DeepCopy() *DORAMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(DORAMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *DORAMetric) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *DORAMetricList) DeepCopyInto(out *DORAMetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]DORAMetric, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *DORAMetricList) DeepCopy() *DORAMetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(DORAMetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *DORAMetricList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+)
+
+// Collector is the subset of MetricsCollector the reconciler depends on.
+// It is satisfied by *main.MetricsCollector; the interface exists so this
+// package has no import back to package main.
+type Collector interface {
+	CollectMetrics(ctx context.Context, dm *metricsv1.DORAMetric) error
+	Unexport(dm *metricsv1.DORAMetric)
+	CanPublish() bool
+}
+
+// DORAMetricReconciler reconciles a DORAMetric object.
+type DORAMetricReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Collector Collector
+}
+
+// Reconcile schedules (or reschedules) collection for the DORAMetric and
+// persists the resulting status.
+func (r *DORAMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	dm := &metricsv1.DORAMetric{}
+	if err := r.Client.Get(ctx, req.NamespacedName, dm); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Collector.Unexport(&metricsv1.DORAMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace},
+			})
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Collector.CollectMetrics(ctx, dm); err != nil {
+		log.Error(err, "failed to (re)schedule metric collection", "dorametric", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	if !r.Collector.CanPublish() {
+		// Another replica is the leader; it owns writing Status.
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Client.Status().Update(ctx, dm); err != nil {
+		log.Error(err, "failed to update DORAMetric status", "dorametric", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *DORAMetricReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metricsv1.DORAMetric{}).
+		Complete(r)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+)
+
+// HealthState backs the manager's /readyz and /healthz checks with real
+// collector state instead of a bare healthz.Ping, and serves per-metric
+// detail at /debug/health.
+type HealthState struct {
+	mu sync.RWMutex
+
+	cronStarted bool
+	scrapes     map[string]scrapeHealth
+}
+
+type scrapeHealth struct {
+	lastSuccess time.Time
+	interval    time.Duration
+}
+
+// NewHealthState returns an empty HealthState; nothing is ready until
+// MarkCronStarted and at least one RecordSuccess have been called.
+func NewHealthState() *HealthState {
+	return &HealthState{scrapes: make(map[string]scrapeHealth)}
+}
+
+// MarkCronStarted records that the cron scheduler has been started.
+func (h *HealthState) MarkCronStarted() {
+	h.mu.Lock()
+	h.cronStarted = true
+	h.mu.Unlock()
+}
+
+// RecordSuccess records that metric on dm was just scraped successfully,
+// deriving the metric's expected interval from its cron schedule so Live
+// can later tell a healthy gap from a stuck scraper.
+func (h *HealthState) RecordSuccess(dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) {
+	interval, err := scheduleInterval(metric.Schedule)
+	if err != nil {
+		interval = 0
+	}
+
+	h.mu.Lock()
+	h.scrapes[scrapeKey(dm, metric.Name)] = scrapeHealth{lastSuccess: time.Now(), interval: interval}
+	h.mu.Unlock()
+}
+
+func scrapeKey(dm *metricsv1.DORAMetric, metricName string) string {
+	return dm.Namespace + "/" + dm.Name + "/" + metricName
+}
+
+// scheduleInterval estimates the gap between consecutive firings of a cron
+// schedule by asking for the next two occurrences from now.
+func scheduleInterval(spec string) (time.Duration, error) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	first := sched.Next(now)
+	second := sched.Next(first)
+	return second.Sub(first), nil
+}
+
+// Ready reports an error until the cron scheduler has started and at least
+// one provider has completed a scrape.
+func (h *HealthState) Ready() error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if !h.cronStarted {
+		return fmt.Errorf("cron scheduler has not started yet")
+	}
+	if len(h.scrapes) == 0 {
+		return fmt.Errorf("no provider has completed a scrape yet")
+	}
+	return nil
+}
+
+// Live reports an error if any tracked metric's last successful scrape is
+// older than staleMultiple times its schedule's interval.
+func (h *HealthState) Live(staleMultiple float64) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	now := time.Now()
+	for key, sh := range h.scrapes {
+		if sh.interval <= 0 {
+			continue // schedule couldn't be parsed; don't fail liveness over it
+		}
+		maxAge := time.Duration(float64(sh.interval) * staleMultiple)
+		if now.Sub(sh.lastSuccess) > maxAge {
+			return fmt.Errorf("%s last succeeded %s ago, exceeding %.1fx its %s schedule interval",
+				key, now.Sub(sh.lastSuccess).Round(time.Second), staleMultiple, sh.interval)
+		}
+	}
+	return nil
+}
+
+// debugHealth is the JSON shape served at /debug/health.
+type debugHealth struct {
+	CronStarted bool                   `json:"cronStarted"`
+	Scrapes     map[string]debugScrape `json:"scrapes"`
+}
+
+type debugScrape struct {
+	LastSuccess time.Time `json:"lastSuccess"`
+	Interval    string    `json:"interval"`
+}
+
+// DebugHandler serves the full per-metric health snapshot as JSON, for
+// operators debugging a readiness or liveness failure.
+func (h *HealthState) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.RLock()
+		out := debugHealth{
+			CronStarted: h.cronStarted,
+			Scrapes:     make(map[string]debugScrape, len(h.scrapes)),
+		}
+		for key, sh := range h.scrapes {
+			out.Scrapes[key] = debugScrape{LastSuccess: sh.lastSuccess, Interval: sh.interval.String()}
+		}
+		h.mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
 	"github.com/jefrnc/dora-operator/controllers"
+	"github.com/jefrnc/dora-operator/providers"
 )
 
 var (
@@ -39,6 +43,24 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	var registryConfig RegistryConfig
+	registryConfig.BindFlags(flag.CommandLine)
+	var configFile string
+	flag.StringVar(&configFile, "config-file", "",
+		"Path to a JSON file of webhook URLs, auth tokens and sample intervals, reloaded on SIGHUP. Disabled if empty.")
+	var publishOnlyWhenLeader bool
+	flag.BoolVar(&publishOnlyWhenLeader, "publish-only-when-leader", true,
+		"Restrict writing DORAMetric status and firing exporters to the leader replica. "+
+			"All replicas compute metrics locally regardless, so a new leader has warm results after failover.")
+	var shardScrapes bool
+	flag.BoolVar(&shardScrapes, "shard-scrapes", false,
+		"Split DORAMetric scrape work across replicas by consistent hashing instead of every replica collecting everything.")
+	var replicaIndex, replicaCount int
+	flag.IntVar(&replicaIndex, "replica-index", 0, "This replica's shard index, used when --shard-scrapes is set.")
+	flag.IntVar(&replicaCount, "replica-count", 1, "Total number of replicas sharing scrape work, used when --shard-scrapes is set.")
+	var livenessStaleMultiple float64
+	flag.Float64Var(&livenessStaleMultiple, "liveness-stale-multiple", 3.0,
+		"Fail the liveness probe once a metric's last successful scrape is this many times older than its schedule interval.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -60,11 +82,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	configStore := NewConfigStore(configFile)
+	if err := configStore.Load(); err != nil {
+		setupLog.Error(err, "unable to load initial config file")
+		os.Exit(1)
+	}
+
+	health := NewHealthState()
+
 	// Create metrics collector
 	collector := &MetricsCollector{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cron:   cron.New(),
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		Cron:                  cron.New(cron.WithChain(cron.Recover(cron.DefaultLogger))),
+		Providers:             providers.NewDefaultRegistry(),
+		Config:                configStore,
+		Leader:                &LeaderState{},
+		PublishOnlyWhenLeader: publishOnlyWhenLeader,
+		Shard:                 ShardConfig{Enabled: shardScrapes, Index: replicaIndex, Count: replicaCount},
+		Health:                health,
 	}
 
 	// Setup controller
@@ -77,18 +113,39 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Add health checks
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+	if err := mgr.Add(&registryConfig); err != nil {
+		setupLog.Error(err, "unable to set up standalone prometheus registry")
+		os.Exit(1)
+	}
+
+	// Add health checks, backed by the collector's actual scrape state
+	// rather than a bare healthz.Ping.
+	if err := mgr.AddHealthzCheck("healthz", func(req *http.Request) error {
+		return health.Live(livenessStaleMultiple)
+	}); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := mgr.AddReadyzCheck("readyz", func(req *http.Request) error {
+		if !mgr.GetCache().WaitForCacheSync(req.Context()) {
+			return fmt.Errorf("informer cache has not synced yet")
+		}
+		return health.Ready()
+	}); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddMetricsExtraHandler("/debug/health", health.DebugHandler()); err != nil {
+		setupLog.Error(err, "unable to add /debug/health handler")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	go configStore.WatchSIGHUP(ctx)
+	go collector.Leader.WatchElection(ctx, mgr.Elected())
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -96,72 +153,202 @@ func main() {
 
 // MetricsCollector handles the actual metric collection
 type MetricsCollector struct {
-	Client runtime.Client
-	Scheme *runtime.Scheme
-	Cron   *cron.Cron
+	Client    client.Client
+	Scheme    *runtime.Scheme
+	Cron      *cron.Cron
+	Providers *providers.Registry
+	Config    *ConfigStore
+
+	// Leader reports whether this replica currently holds the manager's
+	// leader election lock. Every replica computes metrics regardless;
+	// Leader only gates which one is allowed to publish results.
+	Leader *LeaderState
+
+	// PublishOnlyWhenLeader, when true, restricts writing DORAMetric.Status
+	// and firing exporters (Prometheus, webhook) to the leader replica.
+	PublishOnlyWhenLeader bool
+
+	// Shard optionally restricts which DORAMetrics this replica collects
+	// at all, splitting scrape work across replicas by consistent hashing.
+	Shard ShardConfig
+
+	// Health backs the manager's /healthz and /readyz checks.
+	Health *HealthState
+
+	entriesMu sync.Mutex
+	entries   map[apitypes.NamespacedName]map[string]cronEntry
+
+	// latest holds the most recently reconciled DORAMetric for each
+	// NamespacedName, so a scheduled cron job can look up the live spec at
+	// execution time instead of a stale copy captured when its schedule was
+	// first registered.
+	latest map[apitypes.NamespacedName]*metricsv1.DORAMetric
 }
 
-// CollectMetrics collects metrics based on the DORAMetric spec
+// cronEntry remembers which MetricConfig a running cron.EntryID was
+// scheduled for, so a later reconcile can tell whether it's still current.
+type cronEntry struct {
+	id     cron.EntryID
+	metric metricsv1.MetricConfig
+}
+
+// CollectMetrics (re)schedules collection for every enabled metric in the
+// DORAMetric spec. It is safe to call repeatedly for the same DORAMetric:
+// entries for metrics that were disabled, removed, or had their schedule or
+// source changed are replaced rather than duplicated.
 func (mc *MetricsCollector) CollectMetrics(ctx context.Context, dm *metricsv1.DORAMetric) error {
+	key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}
+
+	if !mc.Shard.Owns(dm.UID) {
+		// Another replica owns this DORAMetric's shard; drop any schedule
+		// we might be holding for it and leave collection to that replica.
+		mc.entriesMu.Lock()
+		for _, entry := range mc.entries[key] {
+			mc.Cron.Remove(entry.id)
+		}
+		delete(mc.entries, key)
+		delete(mc.latest, key)
+		mc.entriesMu.Unlock()
+		return nil
+	}
+
+	mc.entriesMu.Lock()
+	defer mc.entriesMu.Unlock()
+
+	if mc.entries == nil {
+		mc.entries = make(map[apitypes.NamespacedName]map[string]cronEntry)
+	}
+	if mc.latest == nil {
+		mc.latest = make(map[apitypes.NamespacedName]*metricsv1.DORAMetric)
+	}
+	mc.latest[key] = dm
+
+	current := mc.entries[key]
+	if current == nil {
+		current = make(map[string]cronEntry)
+		mc.entries[key] = current
+	}
+
+	desired := make(map[string]metricsv1.MetricConfig, len(dm.Spec.Metrics))
 	for _, metric := range dm.Spec.Metrics {
-		if !metric.Enabled {
-			continue
+		if metric.Enabled {
+			desired[metric.Name] = metric
+		}
+	}
+
+	// Drop schedules for metrics that were disabled or removed from the spec.
+	for name, entry := range current {
+		if _, ok := desired[name]; !ok {
+			mc.Cron.Remove(entry.id)
+			delete(current, name)
+		}
+	}
+
+	// (Re)schedule metrics that are new or whose config changed.
+	for name, metric := range desired {
+		if entry, ok := current[name]; ok {
+			if metricConfigsEqual(entry.metric, metric) {
+				continue // unchanged, keep the existing schedule
+			}
+			mc.Cron.Remove(entry.id)
 		}
 
-		// Schedule metric collection
-		_, err := mc.Cron.AddFunc(metric.Schedule, func() {
+		metricName := metric.Name // capture for the closure below
+		id, err := mc.Cron.AddFunc(metric.Schedule, func() {
+			dm, metric, ok := mc.currentMetric(key, metricName)
+			if !ok {
+				return // metric (or the whole DORAMetric) is gone; next reconcile will drop this schedule
+			}
 			if err := mc.collectSingleMetric(ctx, dm, metric); err != nil {
 				setupLog.Error(err, "failed to collect metric",
-					"metric", metric.Name,
-					"dorametric", dm.Name)
+					"metric", metricName,
+					"dorametric", key.Name)
 			}
 		})
 		if err != nil {
 			return fmt.Errorf("failed to schedule metric %s: %w", metric.Name, err)
 		}
+		current[name] = cronEntry{id: id, metric: metric}
 	}
 
 	mc.Cron.Start()
+	if mc.Health != nil {
+		mc.Health.MarkCronStarted()
+	}
 	return nil
 }
 
+// currentMetric returns the DORAMetric last reconciled for key and
+// metricName's current config within it, so a running cron schedule always
+// observes whatever spec.team/service/environment/export/metrics[].* a
+// later reconcile wrote, rather than whatever they were when the schedule
+// was first registered.
+func (mc *MetricsCollector) currentMetric(key apitypes.NamespacedName, metricName string) (*metricsv1.DORAMetric, metricsv1.MetricConfig, bool) {
+	mc.entriesMu.Lock()
+	dm := mc.latest[key]
+	mc.entriesMu.Unlock()
+
+	if dm == nil {
+		return nil, metricsv1.MetricConfig{}, false
+	}
+	for _, m := range dm.Spec.Metrics {
+		if m.Name == metricName && m.Enabled {
+			return dm, m, true
+		}
+	}
+	return nil, metricsv1.MetricConfig{}, false
+}
+
 func (mc *MetricsCollector) collectSingleMetric(ctx context.Context, dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) error {
 	var value float64
 	var err error
 
 	switch metric.Name {
 	case "deployment-frequency":
-		value, err = mc.collectDeploymentFrequency(ctx, dm)
+		value, err = mc.collectDeploymentFrequency(ctx, dm, metric)
 	case "lead-time":
-		value, err = mc.collectLeadTime(ctx, dm)
+		value, err = mc.collectLeadTime(ctx, dm, metric)
 	case "mttr":
-		value, err = mc.collectMTTR(ctx, dm)
+		value, err = mc.collectMTTR(ctx, dm, metric)
 	case "change-failure-rate":
-		value, err = mc.collectChangeFailureRate(ctx, dm)
+		value, err = mc.collectChangeFailureRate(ctx, dm, metric)
 	default:
 		return fmt.Errorf("unknown metric: %s", metric.Name)
 	}
 
+	if dm.Status.Metrics == nil {
+		dm.Status.Metrics = make(map[string]metricsv1.MetricStatus)
+	}
+
 	if err != nil {
+		mc.recordScrapeFailure(dm, metric.Name)
 		// Update status with error
 		dm.Status.Metrics[metric.Name] = metricsv1.MetricStatus{
 			Error:     err.Error(),
 			Timestamp: time.Now().Format(time.RFC3339),
 		}
+		mc.publishStatus(ctx, dm)
 		return err
 	}
 
 	// Update status with value
-	if dm.Status.Metrics == nil {
-		dm.Status.Metrics = make(map[string]metricsv1.MetricStatus)
-	}
-
 	dm.Status.Metrics[metric.Name] = metricsv1.MetricStatus{
 		Value:     value,
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
 	dm.Status.LastCollection = time.Now().Format(time.RFC3339)
 
+	if mc.Health != nil {
+		mc.Health.RecordSuccess(dm, metric)
+	}
+
+	if !mc.CanPublish() {
+		// Another replica is the leader; it owns publishing this result.
+		return nil
+	}
+
+	mc.publishStatus(ctx, dm)
+
 	// Export to Prometheus if enabled
 	if dm.Spec.Export.Prometheus.Enabled {
 		if err := mc.exportToPrometheus(dm, metric.Name, value); err != nil {
@@ -176,38 +363,50 @@ func (mc *MetricsCollector) collectSingleMetric(ctx context.Context, dm *metrics
 		}
 	}
 
-	return nil
-}
+	// Export to an OTel collector if enabled
+	if dm.Spec.Export.OTLP.Enabled {
+		if err := mc.exportToOTLP(ctx, dm, metric.Name, value); err != nil {
+			setupLog.Error(err, "failed to export to otlp collector")
+		}
+	}
 
-func (mc *MetricsCollector) collectDeploymentFrequency(ctx context.Context, dm *metricsv1.DORAMetric) (float64, error) {
-	// Implementation would call the actual metric collection logic
-	// This is a placeholder
-	return 5.2, nil
+	return nil
 }
 
-func (mc *MetricsCollector) collectLeadTime(ctx context.Context, dm *metricsv1.DORAMetric) (float64, error) {
-	// Implementation would call the actual metric collection logic
-	return 18.5, nil
+// publishStatus persists dm's Status to the API server. CollectMetrics only
+// schedules collection; the actual status mutations happen later, inside a
+// cron closure, so this is the only place that ever writes them back —
+// Reconcile's own Status().Update runs too early to see them.
+func (mc *MetricsCollector) publishStatus(ctx context.Context, dm *metricsv1.DORAMetric) {
+	if !mc.CanPublish() {
+		// Another replica is the leader; it owns writing Status.
+		return
+	}
+	if err := mc.Client.Status().Update(ctx, dm); err != nil {
+		setupLog.Error(err, "failed to update DORAMetric status", "dorametric", dm.Name, "namespace", dm.Namespace)
+	}
 }
 
-func (mc *MetricsCollector) collectMTTR(ctx context.Context, dm *metricsv1.DORAMetric) (float64, error) {
-	// Implementation would call the actual metric collection logic
-	return 45.3, nil
+func (mc *MetricsCollector) collectDeploymentFrequency(ctx context.Context, dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) (float64, error) {
+	return mc.collectFromProvider(ctx, dm, metric, func(p providers.Provider, ctx context.Context, window providers.Window, filters providers.Filters) (float64, error) {
+		return p.DeploymentFrequency(ctx, window, filters)
+	})
 }
 
-func (mc *MetricsCollector) collectChangeFailureRate(ctx context.Context, dm *metricsv1.DORAMetric) (float64, error) {
-	// Implementation would call the actual metric collection logic
-	return 12.5, nil
+func (mc *MetricsCollector) collectLeadTime(ctx context.Context, dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) (float64, error) {
+	return mc.collectFromProvider(ctx, dm, metric, func(p providers.Provider, ctx context.Context, window providers.Window, filters providers.Filters) (float64, error) {
+		return p.LeadTime(ctx, window, filters)
+	})
 }
 
-func (mc *MetricsCollector) exportToPrometheus(dm *metricsv1.DORAMetric, metricName string, value float64) error {
-	// Export metric to Prometheus
-	// This would use prometheus client library
-	return nil
+func (mc *MetricsCollector) collectMTTR(ctx context.Context, dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) (float64, error) {
+	return mc.collectFromProvider(ctx, dm, metric, func(p providers.Provider, ctx context.Context, window providers.Window, filters providers.Filters) (float64, error) {
+		return p.MTTR(ctx, window, filters)
+	})
 }
 
-func (mc *MetricsCollector) sendWebhook(dm *metricsv1.DORAMetric, metricName string, value float64) error {
-	// Send webhook notification
-	// This would make HTTP request to configured webhook URL
-	return nil
+func (mc *MetricsCollector) collectChangeFailureRate(ctx context.Context, dm *metricsv1.DORAMetric, metric metricsv1.MetricConfig) (float64, error) {
+	return mc.collectFromProvider(ctx, dm, metric, func(p providers.Provider, ctx context.Context, window providers.Window, filters providers.Filters) (float64, error) {
+		return p.ChangeFailureRate(ctx, window, filters)
+	})
 }
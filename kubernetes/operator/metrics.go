@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+)
+
+// doraMetricLabels is shared by every per-metric gauge below.
+var doraMetricLabels = []string{"dorametric", "namespace", "team", "service", "environment"}
+
+var (
+	doraDeploymentFrequency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dora_deployment_frequency",
+		Help: "Deployments observed in the DORAMetric's collection window.",
+	}, doraMetricLabels)
+
+	doraLeadTimeHours = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dora_lead_time_hours",
+		Help: "Mean lead time for change in hours, as last collected.",
+	}, doraMetricLabels)
+
+	doraMTTRMinutes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dora_mttr_minutes",
+		Help: "Mean time to recovery in minutes, as last collected.",
+	}, doraMetricLabels)
+
+	doraChangeFailureRatePercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dora_change_failure_rate_percent",
+		Help: "Percentage of deployments requiring remediation, as last collected.",
+	}, doraMetricLabels)
+
+	doraScrapeFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dora_scrape_failures_total",
+		Help: "Total number of failed metric collection attempts, by DORAMetric and metric name.",
+	}, []string{"dorametric", "namespace", "metric"})
+
+	doraLastScrapeTimestampSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dora_last_scrape_timestamp_seconds",
+		Help: "Unix timestamp of the last scrape attempt for a DORAMetric's metric, successful or not.",
+	}, []string{"dorametric", "namespace", "metric"})
+
+	doraWebhookDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dora_webhook_deliveries_total",
+		Help: "Total webhook delivery attempts, by sink and outcome (delivered, filtered, failed).",
+	}, []string{"dorametric", "namespace", "sink", "outcome"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		doraDeploymentFrequency,
+		doraLeadTimeHours,
+		doraMTTRMinutes,
+		doraChangeFailureRatePercent,
+		doraScrapeFailuresTotal,
+		doraLastScrapeTimestampSeconds,
+		doraWebhookDeliveriesTotal,
+	)
+}
+
+// metricGaugeFor returns the GaugeVec that holds metricName's current value,
+// or nil if metricName isn't one of the four DORA metrics.
+func metricGaugeFor(metricName string) *prometheus.GaugeVec {
+	switch metricName {
+	case "deployment-frequency":
+		return doraDeploymentFrequency
+	case "lead-time":
+		return doraLeadTimeHours
+	case "mttr":
+		return doraMTTRMinutes
+	case "change-failure-rate":
+		return doraChangeFailureRatePercent
+	default:
+		return nil
+	}
+}
+
+// exportToPrometheus records value on the GaugeVec for metricName, labeled
+// with the DORAMetric's identity.
+func (mc *MetricsCollector) exportToPrometheus(dm *metricsv1.DORAMetric, metricName string, value float64) error {
+	gauge := metricGaugeFor(metricName)
+	if gauge == nil {
+		return fmt.Errorf("no prometheus gauge registered for metric %q", metricName)
+	}
+	gauge.With(mc.doraLabels(dm)).Set(value)
+	doraLastScrapeTimestampSeconds.WithLabelValues(dm.Name, dm.Namespace, metricName).Set(float64(time.Now().Unix()))
+	return nil
+}
+
+// recordScrapeFailure increments the scrape failure counter for a metric
+// and still stamps the last-scrape timestamp, so a stuck collector shows up
+// as a gap between its last timestamp and now rather than silence.
+func (mc *MetricsCollector) recordScrapeFailure(dm *metricsv1.DORAMetric, metricName string) {
+	doraScrapeFailuresTotal.WithLabelValues(dm.Name, dm.Namespace, metricName).Inc()
+	doraLastScrapeTimestampSeconds.WithLabelValues(dm.Name, dm.Namespace, metricName).Set(float64(time.Now().Unix()))
+}
+
+// Unexport removes every gauge series and scheduled cron entry for dm,
+// called when the DORAMetric CR is deleted so stale series and schedules
+// don't linger forever. dm is a throwaway object carrying only the deleted
+// CR's name/namespace, so its labels are looked up from the last fully
+// reconciled copy of the object rather than re-derived from dm itself -
+// otherwise team/service/environment would be empty and gauge.Delete would
+// never match the series that was actually registered.
+func (mc *MetricsCollector) Unexport(dm *metricsv1.DORAMetric) {
+	mc.entriesMu.Lock()
+	key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}
+	for _, entry := range mc.entries[key] {
+		mc.Cron.Remove(entry.id)
+	}
+	delete(mc.entries, key)
+
+	labels := mc.doraLabels(dm)
+	if known, ok := mc.latest[key]; ok {
+		labels = mc.doraLabels(known)
+	}
+	delete(mc.latest, key)
+	mc.entriesMu.Unlock()
+
+	for _, metricName := range []string{"deployment-frequency", "lead-time", "mttr", "change-failure-rate"} {
+		if gauge := metricGaugeFor(metricName); gauge != nil {
+			gauge.Delete(labels)
+		}
+		doraScrapeFailuresTotal.DeleteLabelValues(dm.Name, dm.Namespace, metricName)
+		doraLastScrapeTimestampSeconds.DeleteLabelValues(dm.Name, dm.Namespace, metricName)
+	}
+}
+
+func (mc *MetricsCollector) doraLabels(dm *metricsv1.DORAMetric) prometheus.Labels {
+	return prometheus.Labels{
+		"dorametric":  dm.Name,
+		"namespace":   dm.Namespace,
+		"team":        dm.Spec.Team,
+		"service":     dm.Spec.Service,
+		"environment": dm.Spec.Environment,
+	}
+}
+
+// RegistryConfig configures a standalone Prometheus scrape endpoint for
+// ctrlmetrics.Registry, independent of the manager's own metrics server.
+// This mirrors Cilium's pkg/metrics/registry.go, which lets agents expose
+// their registry on an address separate from the rest of the process.
+type RegistryConfig struct {
+	// ServeAddr is the address the standalone /metrics endpoint binds to.
+	// An empty value disables the standalone server.
+	ServeAddr string
+}
+
+// BindFlags registers the --prometheus-serve-addr flag on fs.
+func (c *RegistryConfig) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.ServeAddr, "prometheus-serve-addr", "",
+		"Address for a standalone Prometheus /metrics endpoint, in addition to --metrics-bind-address. Disabled if empty.")
+}
+
+// Start implements manager.Runnable, serving the registry until ctx is done.
+func (c *RegistryConfig) Start(ctx context.Context) error {
+	if c.ServeAddr == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(ctrlmetrics.Registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: c.ServeAddr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		return srv.Shutdown(context.Background())
+	case err := <-errCh:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	}
+}
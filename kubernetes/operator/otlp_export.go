@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc/credentials"
+
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+)
+
+// otlpMeters caches one MeterProvider per distinct collector target
+// (endpoint, protocol and auth), so every DORAMetric sharing an OTel
+// collector shares a single exporter and batching pipeline rather than
+// opening a new connection per CR.
+type otlpMeters struct {
+	mu        sync.Mutex
+	providers map[string]metric.Meter
+}
+
+var otlpMetersCache = &otlpMeters{providers: make(map[string]metric.Meter)}
+
+// exportToOTLP records value as an OTLP gauge for metricName, tagged with
+// attributes derived from dm's labels (team, service, environment) plus its
+// own dimensions. These are per-point attributes rather than baked into the
+// shared MeterProvider's Resource, since one MeterProvider is reused across
+// every DORAMetric pointed at the same collector endpoint.
+func (mc *MetricsCollector) exportToOTLP(ctx context.Context, dm *metricsv1.DORAMetric, metricName string, value float64) error {
+	cfg := dm.Spec.Export.OTLP
+
+	auth, err := mc.resolveOTLPAuth(ctx, dm, cfg)
+	if err != nil {
+		return fmt.Errorf("resolving otlp auth: %w", err)
+	}
+
+	meter, err := otlpMetersCache.meterFor(ctx, cfg, auth)
+	if err != nil {
+		return fmt.Errorf("setting up otlp meter: %w", err)
+	}
+
+	gauge, err := meter.Float64Gauge(
+		"dora."+metricName,
+		metric.WithDescription(fmt.Sprintf("DORA %s, as last collected by dora-operator.", metricName)),
+	)
+	if err != nil {
+		return fmt.Errorf("creating otlp gauge for %s: %w", metricName, err)
+	}
+
+	gauge.Record(ctx, value, metric.WithAttributes(
+		attribute.String("dorametric", dm.Name),
+		attribute.String("namespace", dm.Namespace),
+		semconv.ServiceName(dm.Spec.Service),
+		attribute.String("deployment.environment", dm.Spec.Environment),
+		attribute.String("team", dm.Spec.Team),
+	))
+	return nil
+}
+
+// otlpAuth carries the resolved auth material for one collector target.
+type otlpAuth struct {
+	bearerToken string
+	tlsConfig   *tls.Config
+}
+
+// resolveOTLPAuth loads the bearer token and/or mTLS client certificate
+// cfg references from Secrets in dm's namespace.
+func (mc *MetricsCollector) resolveOTLPAuth(ctx context.Context, dm *metricsv1.DORAMetric, cfg metricsv1.OTLPExportConfig) (otlpAuth, error) {
+	var auth otlpAuth
+
+	if cfg.BearerTokenSecretRef != nil && cfg.BearerTokenSecretRef.Name != "" {
+		var secret corev1.Secret
+		key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: cfg.BearerTokenSecretRef.Name}
+		if err := mc.Client.Get(ctx, key, &secret); err != nil {
+			return auth, fmt.Errorf("resolving bearer token secret %s: %w", key, err)
+		}
+		token, ok := secret.Data["token"]
+		if !ok {
+			return auth, fmt.Errorf("secret %s has no %q key", key, "token")
+		}
+		auth.bearerToken = string(token)
+	}
+
+	if !cfg.Insecure && cfg.TLSSecretRef != nil && cfg.TLSSecretRef.Name != "" {
+		var secret corev1.Secret
+		key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: cfg.TLSSecretRef.Name}
+		if err := mc.Client.Get(ctx, key, &secret); err != nil {
+			return auth, fmt.Errorf("resolving tls secret %s: %w", key, err)
+		}
+		cert, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+		if err != nil {
+			return auth, fmt.Errorf("parsing client certificate from secret %s: %w", key, err)
+		}
+		pool := x509.NewCertPool()
+		if ca, ok := secret.Data["ca.crt"]; ok {
+			pool.AppendCertsFromPEM(ca)
+		}
+		auth.tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: pool}
+	}
+
+	return auth, nil
+}
+
+// meterFor returns the shared Meter for cfg's endpoint/protocol/auth,
+// creating the underlying MeterProvider and exporter on first use. The
+// Resource identifies the operator process itself, not any one DORAMetric —
+// per-CR identity (team, service, environment) is attached to each data
+// point instead, since every CR sharing this destination shares this Meter.
+func (c *otlpMeters) meterFor(ctx context.Context, cfg metricsv1.OTLPExportConfig, auth otlpAuth) (metric.Meter, error) {
+	key := fmt.Sprintf("%s|%s|%t|%s", cfg.Endpoint, cfg.Protocol, cfg.Insecure, auth.bearerToken)
+	if auth.tlsConfig != nil && len(auth.tlsConfig.Certificates) > 0 && len(auth.tlsConfig.Certificates[0].Certificate) > 0 {
+		key += "|" + string(auth.tlsConfig.Certificates[0].Certificate[0])
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if meter, ok := c.providers[key]; ok {
+		return meter, nil
+	}
+
+	exporter, err := newOTLPExporter(ctx, cfg, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(resource.Default()),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+	)
+	meter := provider.Meter("github.com/jefrnc/dora-operator")
+	c.providers[key] = meter
+	return meter, nil
+}
+
+func newOTLPExporter(ctx context.Context, cfg metricsv1.OTLPExportConfig, auth otlpAuth) (sdkmetric.Exporter, error) {
+	headers := map[string]string{}
+	if auth.bearerToken != "" {
+		headers["Authorization"] = "Bearer " + auth.bearerToken
+	}
+
+	if cfg.Protocol == "http" {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(cfg.Endpoint),
+			otlpmetrichttp.WithHeaders(headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		} else if auth.tlsConfig != nil {
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(auth.tlsConfig))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+		otlpmetricgrpc.WithHeaders(headers),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else if auth.tlsConfig != nil {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(auth.tlsConfig)))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
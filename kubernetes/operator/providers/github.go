@@ -0,0 +1,125 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const githubDefaultEndpoint = "https://api.github.com"
+
+// GitHubProvider computes deployment frequency and lead time for change
+// from a repository's GitHub Actions workflow runs and merged pull requests.
+type GitHubProvider struct{}
+
+// NewGitHubProvider returns a Provider backed by the GitHub REST API.
+func NewGitHubProvider() *GitHubProvider { return &GitHubProvider{} }
+
+func (p *GitHubProvider) Name() string { return "github" }
+
+type githubWorkflowRunsResponse struct {
+	TotalCount   int `json:"total_count"`
+	WorkflowRuns []struct {
+		Status     string    `json:"status"`
+		Conclusion string    `json:"conclusion"`
+		CreatedAt  time.Time `json:"created_at"`
+	} `json:"workflow_runs"`
+}
+
+// DeploymentFrequency counts successful workflow runs created in window.
+// Runs are not filtered by workflow name.
+func (p *GitHubProvider) DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Repo == "" {
+		return 0, fmt.Errorf("providers/github: filters.Repo is required")
+	}
+	endpoint := githubDefaultEndpoint
+	if filters.Endpoint != "" {
+		endpoint = filters.Endpoint
+	}
+	url := fmt.Sprintf("%s/repos/%s/actions/runs?status=success&created=%s..%s",
+		endpoint, filters.Repo, window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+
+	var runs githubWorkflowRunsResponse
+	if err := p.get(ctx, filters, url, &runs); err != nil {
+		return 0, err
+	}
+
+	var deployments int
+	for _, run := range runs.WorkflowRuns {
+		if run.Conclusion == "success" {
+			deployments++
+		}
+	}
+	return float64(deployments), nil
+}
+
+type githubPullRequest struct {
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+// LeadTime averages the hours between a pull request's creation and its
+// merge, across pull requests merged within window.
+func (p *GitHubProvider) LeadTime(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Repo == "" {
+		return 0, fmt.Errorf("providers/github: filters.Repo is required")
+	}
+	endpoint := githubDefaultEndpoint
+	if filters.Endpoint != "" {
+		endpoint = filters.Endpoint
+	}
+	url := fmt.Sprintf("%s/repos/%s/pulls?state=closed&sort=updated&direction=desc", endpoint, filters.Repo)
+
+	var prs []githubPullRequest
+	if err := p.get(ctx, filters, url, &prs); err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	var count int
+	for _, pr := range prs {
+		if pr.MergedAt == nil || pr.MergedAt.Before(window.Since) || pr.MergedAt.After(window.Until) {
+			continue
+		}
+		total += pr.MergedAt.Sub(pr.CreatedAt)
+		count++
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return total.Hours() / float64(count), nil
+}
+
+// MTTR is not derivable from GitHub alone.
+func (p *GitHubProvider) MTTR(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// ChangeFailureRate is not derivable from GitHub alone.
+func (p *GitHubProvider) ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+func (p *GitHubProvider) get(ctx context.Context, filters Filters, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if filters.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+filters.AuthToken)
+	}
+
+	resp, err := filters.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("providers/github: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("providers/github: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const gitlabDefaultEndpoint = "https://gitlab.com/api/v4"
+
+// GitLabProvider computes deployment frequency and lead time for change
+// from a project's pipeline and merge request history.
+type GitLabProvider struct{}
+
+// NewGitLabProvider returns a Provider backed by the GitLab REST API.
+func NewGitLabProvider() *GitLabProvider { return &GitLabProvider{} }
+
+func (p *GitLabProvider) Name() string { return "gitlab" }
+
+type gitlabPipeline struct {
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeploymentFrequency counts successful pipelines last updated within
+// window. Pipelines on every branch are counted, not only the default one.
+func (p *GitLabProvider) DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Project == "" {
+		return 0, fmt.Errorf("providers/gitlab: filters.Project is required")
+	}
+	u := fmt.Sprintf("%s/projects/%s/pipelines?status=success&updated_after=%s&updated_before=%s",
+		p.endpoint(filters), url.PathEscape(filters.Project),
+		window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+
+	var pipelines []gitlabPipeline
+	if err := p.get(ctx, filters, u, &pipelines); err != nil {
+		return 0, err
+	}
+	return float64(len(pipelines)), nil
+}
+
+type gitlabMergeRequest struct {
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+// LeadTime averages the hours between a merge request's creation and merge.
+func (p *GitLabProvider) LeadTime(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Project == "" {
+		return 0, fmt.Errorf("providers/gitlab: filters.Project is required")
+	}
+	u := fmt.Sprintf("%s/projects/%s/merge_requests?state=merged", p.endpoint(filters), url.PathEscape(filters.Project))
+
+	var mrs []gitlabMergeRequest
+	if err := p.get(ctx, filters, u, &mrs); err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	var count int
+	for _, mr := range mrs {
+		if mr.MergedAt == nil || mr.MergedAt.Before(window.Since) || mr.MergedAt.After(window.Until) {
+			continue
+		}
+		total += mr.MergedAt.Sub(mr.CreatedAt)
+		count++
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return total.Hours() / float64(count), nil
+}
+
+// MTTR is not derivable from GitLab alone.
+func (p *GitLabProvider) MTTR(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// ChangeFailureRate is not derivable from GitLab alone.
+func (p *GitLabProvider) ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+func (p *GitLabProvider) endpoint(filters Filters) string {
+	if filters.Endpoint != "" {
+		return filters.Endpoint
+	}
+	return gitlabDefaultEndpoint
+}
+
+func (p *GitLabProvider) get(ctx context.Context, filters Filters, u string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if filters.AuthToken != "" {
+		req.Header.Set("PRIVATE-TOKEN", filters.AuthToken)
+	}
+
+	resp, err := filters.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("providers/gitlab: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("providers/gitlab: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// JenkinsProvider computes deployment frequency from a job's build history.
+type JenkinsProvider struct{}
+
+// NewJenkinsProvider returns a Provider backed by the Jenkins JSON API.
+func NewJenkinsProvider() *JenkinsProvider { return &JenkinsProvider{} }
+
+func (p *JenkinsProvider) Name() string { return "jenkins" }
+
+type jenkinsBuildHistory struct {
+	Builds []struct {
+		Result    string `json:"result"`
+		Timestamp int64  `json:"timestamp"` // epoch millis
+	} `json:"builds"`
+}
+
+// DeploymentFrequency counts successful builds of filters.Project within window.
+func (p *JenkinsProvider) DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error) {
+	history, err := p.buildHistory(ctx, filters)
+	if err != nil {
+		return 0, err
+	}
+
+	var deployments int
+	for _, build := range history.Builds {
+		ts := time.UnixMilli(build.Timestamp)
+		if build.Result == "SUCCESS" && !ts.Before(window.Since) && !ts.After(window.Until) {
+			deployments++
+		}
+	}
+	return float64(deployments), nil
+}
+
+// LeadTime is not derivable from Jenkins build history alone.
+func (p *JenkinsProvider) LeadTime(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// MTTR is not derivable from Jenkins build history alone.
+func (p *JenkinsProvider) MTTR(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// ChangeFailureRate is the share of builds in window that did not succeed.
+func (p *JenkinsProvider) ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error) {
+	history, err := p.buildHistory(ctx, filters)
+	if err != nil {
+		return 0, err
+	}
+
+	var total, failed int
+	for _, build := range history.Builds {
+		ts := time.UnixMilli(build.Timestamp)
+		if ts.Before(window.Since) || ts.After(window.Until) {
+			continue
+		}
+		total++
+		if build.Result != "SUCCESS" {
+			failed++
+		}
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(failed) / float64(total) * 100, nil
+}
+
+// buildHistory fetches filters.Project's build history, shared by
+// DeploymentFrequency and ChangeFailureRate since both need the same feed.
+func (p *JenkinsProvider) buildHistory(ctx context.Context, filters Filters) (jenkinsBuildHistory, error) {
+	if filters.Project == "" {
+		return jenkinsBuildHistory{}, fmt.Errorf("providers/jenkins: filters.Project is required")
+	}
+	if filters.Endpoint == "" {
+		return jenkinsBuildHistory{}, fmt.Errorf("providers/jenkins: filters.Endpoint is required")
+	}
+	url := fmt.Sprintf("%s/job/%s/api/json?tree=builds[result,timestamp]", filters.Endpoint, filters.Project)
+
+	var history jenkinsBuildHistory
+	if err := p.get(ctx, filters, url, &history); err != nil {
+		return jenkinsBuildHistory{}, err
+	}
+	return history, nil
+}
+
+func (p *JenkinsProvider) get(ctx context.Context, filters Filters, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if filters.AuthToken != "" {
+		req.SetBasicAuth("api", filters.AuthToken)
+	}
+
+	resp, err := filters.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("providers/jenkins: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("providers/jenkins: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const pagerdutyDefaultEndpoint = "https://api.pagerduty.com"
+
+// PagerDutyProvider computes MTTR from a service's resolved incidents.
+type PagerDutyProvider struct{}
+
+// NewPagerDutyProvider returns a Provider backed by the PagerDuty REST API.
+func NewPagerDutyProvider() *PagerDutyProvider { return &PagerDutyProvider{} }
+
+func (p *PagerDutyProvider) Name() string { return "pagerduty" }
+
+type pagerdutyIncidentsResponse struct {
+	Incidents []struct {
+		CreatedAt  time.Time  `json:"created_at"`
+		ResolvedAt *time.Time `json:"last_status_change_at"`
+		Status     string     `json:"status"`
+	} `json:"incidents"`
+}
+
+// MTTR averages the minutes between an incident's creation and resolution
+// for incidents resolved within window.
+func (p *PagerDutyProvider) MTTR(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Service == "" {
+		return 0, fmt.Errorf("providers/pagerduty: filters.Service is required")
+	}
+	endpoint := pagerdutyDefaultEndpoint
+	if filters.Endpoint != "" {
+		endpoint = filters.Endpoint
+	}
+	url := fmt.Sprintf("%s/incidents?service_ids[]=%s&statuses[]=resolved&since=%s&until=%s",
+		endpoint, filters.Service, window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+
+	var incidents pagerdutyIncidentsResponse
+	if err := p.get(ctx, filters, url, &incidents); err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	var count int
+	for _, incident := range incidents.Incidents {
+		if incident.ResolvedAt == nil {
+			continue
+		}
+		total += incident.ResolvedAt.Sub(incident.CreatedAt)
+		count++
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return total.Minutes() / float64(count), nil
+}
+
+// DeploymentFrequency is not derivable from PagerDuty alone.
+func (p *PagerDutyProvider) DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// LeadTime is not derivable from PagerDuty alone.
+func (p *PagerDutyProvider) LeadTime(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// ChangeFailureRate is not derivable from PagerDuty alone.
+func (p *PagerDutyProvider) ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+func (p *PagerDutyProvider) get(ctx context.Context, filters Filters, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	if filters.AuthToken != "" {
+		req.Header.Set("Authorization", "Token token="+filters.AuthToken)
+	}
+
+	resp, err := filters.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("providers/pagerduty: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("providers/pagerduty: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,116 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const prometheusDefaultEndpoint = "http://localhost:9090"
+
+// PrometheusProvider computes change failure rate from the
+// deployment_failed_total and deployment_total counters. It does not read
+// ALERTS or ALERTS_FOR_STATE.
+type PrometheusProvider struct{}
+
+// NewPrometheusProvider returns a Provider backed by the Prometheus HTTP API.
+func NewPrometheusProvider() *PrometheusProvider { return &PrometheusProvider{} }
+
+func (p *PrometheusProvider) Name() string { return "prometheus" }
+
+type prometheusQueryResponse struct {
+	Data struct {
+		Result []struct {
+			Value [2]interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// ChangeFailureRate queries the increase of deployment_failed_total and of
+// deployment_total for filters.Service over window, returning the failed
+// share as a percentage.
+func (p *PrometheusProvider) ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error) {
+	if filters.Service == "" {
+		return 0, fmt.Errorf("providers/prometheus: filters.Service is required")
+	}
+
+	failed, err := p.scalarQuery(ctx, filters,
+		fmt.Sprintf(`sum(increase(deployment_failed_total{service=%q}[%s]))`, filters.Service, promRange(window)))
+	if err != nil {
+		return 0, err
+	}
+	total, err := p.scalarQuery(ctx, filters,
+		fmt.Sprintf(`sum(increase(deployment_total{service=%q}[%s]))`, filters.Service, promRange(window)))
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return failed / total * 100, nil
+}
+
+// DeploymentFrequency is not derivable from Prometheus alerts alone.
+func (p *PrometheusProvider) DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// LeadTime is not derivable from Prometheus alerts alone.
+func (p *PrometheusProvider) LeadTime(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+// MTTR is not derivable from Prometheus alerts alone.
+func (p *PrometheusProvider) MTTR(ctx context.Context, window Window, filters Filters) (float64, error) {
+	return 0, ErrUnsupportedMetric
+}
+
+func promRange(window Window) string {
+	d := window.Until.Sub(window.Since)
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+func (p *PrometheusProvider) scalarQuery(ctx context.Context, filters Filters, promQL string) (float64, error) {
+	endpoint := prometheusDefaultEndpoint
+	if filters.Endpoint != "" {
+		endpoint = filters.Endpoint
+	}
+	reqURL := fmt.Sprintf("%s/api/v1/query?query=%s", endpoint, url.QueryEscape(promQL))
+
+	var result prometheusQueryResponse
+	if err := p.get(ctx, filters, reqURL, &result); err != nil {
+		return 0, err
+	}
+	if len(result.Data.Result) == 0 {
+		return 0, nil
+	}
+
+	var value float64
+	if _, err := fmt.Sscanf(fmt.Sprint(result.Data.Result[0].Value[1]), "%f", &value); err != nil {
+		return 0, fmt.Errorf("providers/prometheus: unparseable scalar value: %w", err)
+	}
+	return value, nil
+}
+
+func (p *PrometheusProvider) get(ctx context.Context, filters Filters, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if filters.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+filters.AuthToken)
+	}
+
+	resp, err := filters.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("providers/prometheus: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("providers/prometheus: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
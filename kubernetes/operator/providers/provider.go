@@ -0,0 +1,113 @@
+// Package providers implements the pluggable metric source backends used by
+// the DORA operator's collector. Each Provider knows how to talk to one
+// upstream system (GitHub, GitLab, Jenkins, ArgoCD, PagerDuty, Prometheus)
+// and turn its data into one or more of the four DORA metrics.
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrUnsupportedMetric is returned by a Provider for a metric it has no
+// data source for, e.g. asking the PagerDuty provider for lead time.
+var ErrUnsupportedMetric = errors.New("providers: metric not supported by this provider")
+
+// Window bounds the time range a metric is computed over.
+type Window struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Filters narrows a provider's query down to a single repo, project,
+// namespace or service, and carries the credentials to reach it.
+type Filters struct {
+	Repo      string // "owner/name", github
+	Project   string // Jenkins job path or GitLab project path
+	Namespace string // argocd/kubernetes namespace
+	Service   string // PagerDuty service ID, or Prometheus service label
+	Endpoint  string // overrides the provider's default API base URL
+	AuthToken string // bearer/API token resolved from the CR's secretRef
+
+	// HTTPClient lets callers (notably tests) inject a client pointed at a
+	// fake server. A nil value means http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (f Filters) httpClient() *http.Client {
+	if f.HTTPClient != nil {
+		return f.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Provider computes DORA metrics from a single upstream system. All four
+// methods are part of the interface so the collector can treat every
+// provider uniformly; a provider that has no data for a given metric
+// returns ErrUnsupportedMetric rather than a zero value.
+type Provider interface {
+	// Name identifies the provider in DORAMetric.spec.metrics[].source.provider.
+	Name() string
+
+	// DeploymentFrequency returns the number of deployments observed in window.
+	DeploymentFrequency(ctx context.Context, window Window, filters Filters) (float64, error)
+
+	// LeadTime returns the mean lead time for change, in hours. The built-in
+	// providers measure it from pull/merge request creation to merge.
+	LeadTime(ctx context.Context, window Window, filters Filters) (float64, error)
+
+	// MTTR returns the mean time to recovery, in minutes, for incidents in window.
+	MTTR(ctx context.Context, window Window, filters Filters) (float64, error)
+
+	// ChangeFailureRate returns the percentage of deployments in window that
+	// required remediation (rollback, hotfix or triggered incident).
+	ChangeFailureRate(ctx context.Context, window Window, filters Filters) (float64, error)
+}
+
+// Registry holds the set of providers the operator knows how to use,
+// keyed by the name referenced from DORAMetric.spec.metrics[].source.provider.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+// Register adds p to the registry under p.Name(), replacing any existing
+// provider registered under the same name.
+func (r *Registry) Register(p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[p.Name()] = p
+}
+
+// Get returns the provider registered under name, or an error if none is.
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("providers: no provider registered under %q", name)
+	}
+	return p, nil
+}
+
+// NewDefaultRegistry returns a Registry with all built-in providers
+// registered under their canonical names.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(NewGitHubProvider())
+	r.Register(NewGitLabProvider())
+	r.Register(NewJenkinsProvider())
+	r.Register(NewArgoCDProvider())
+	r.Register(NewPagerDutyProvider())
+	r.Register(NewPrometheusProvider())
+	return r
+}
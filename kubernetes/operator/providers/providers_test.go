@@ -0,0 +1,161 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixtureServer(t *testing.T, path string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", path, err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+}
+
+func testWindow() Window {
+	since, _ := time.Parse(time.RFC3339, "2024-01-09T00:00:00Z")
+	until, _ := time.Parse(time.RFC3339, "2024-01-14T00:00:00Z")
+	return Window{Since: since, Until: until}
+}
+
+func TestGitHubDeploymentFrequency(t *testing.T) {
+	srv := fixtureServer(t, "testdata/github_workflow_runs.json")
+	defer srv.Close()
+
+	p := NewGitHubProvider()
+	got, err := p.DeploymentFrequency(context.Background(), testWindow(), Filters{
+		Repo: "acme/app", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("DeploymentFrequency = %v, want 2", got)
+	}
+}
+
+func TestGitLabDeploymentFrequency(t *testing.T) {
+	srv := fixtureServer(t, "testdata/gitlab_pipelines.json")
+	defer srv.Close()
+
+	p := NewGitLabProvider()
+	got, err := p.DeploymentFrequency(context.Background(), testWindow(), Filters{
+		Project: "acme/app", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("DeploymentFrequency = %v, want 2", got)
+	}
+}
+
+func TestJenkinsChangeFailureRate(t *testing.T) {
+	srv := fixtureServer(t, "testdata/jenkins_builds.json")
+	defer srv.Close()
+
+	p := NewJenkinsProvider()
+	got, err := p.ChangeFailureRate(context.Background(), testWindow(), Filters{
+		Project: "app-deploy", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := float64(1) / float64(3) * 100
+	if got != want {
+		t.Errorf("ChangeFailureRate = %v, want %v", got, want)
+	}
+}
+
+func TestJenkinsLeadTimeUnsupported(t *testing.T) {
+	p := NewJenkinsProvider()
+	if _, err := p.LeadTime(context.Background(), testWindow(), Filters{}); err != ErrUnsupportedMetric {
+		t.Errorf("LeadTime error = %v, want ErrUnsupportedMetric", err)
+	}
+}
+
+func TestArgoCDDeploymentFrequency(t *testing.T) {
+	srv := fixtureServer(t, "testdata/argocd_application.json")
+	defer srv.Close()
+
+	p := NewArgoCDProvider()
+	got, err := p.DeploymentFrequency(context.Background(), testWindow(), Filters{
+		Project: "app", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("DeploymentFrequency = %v, want 2", got)
+	}
+}
+
+func TestPagerDutyMTTR(t *testing.T) {
+	srv := fixtureServer(t, "testdata/pagerduty_incidents.json")
+	defer srv.Close()
+
+	p := NewPagerDutyProvider()
+	got, err := p.MTTR(context.Background(), testWindow(), Filters{
+		Service: "PXXXXXX", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := (30.0 + 90.0) / 2
+	if got != want {
+		t.Errorf("MTTR = %v, want %v", got, want)
+	}
+}
+
+func TestPrometheusChangeFailureRate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query().Get("query")
+		switch {
+		case strings.Contains(query, "deployment_failed_total"):
+			w.Write([]byte(`{"data":{"result":[{"value":[0,"2"]}]}}`))
+		case strings.Contains(query, "deployment_total"):
+			w.Write([]byte(`{"data":{"result":[{"value":[0,"8"]}]}}`))
+		default:
+			w.Write([]byte(`{"data":{"result":[]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := NewPrometheusProvider()
+	got, err := p.ChangeFailureRate(context.Background(), testWindow(), Filters{
+		Service: "checkout", Endpoint: srv.URL, HTTPClient: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 25 {
+		t.Errorf("ChangeFailureRate = %v, want 25", got)
+	}
+}
+
+func TestRegistryGetUnknownProvider(t *testing.T) {
+	r := NewRegistry()
+	if _, err := r.Get("made-up"); err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+}
+
+func TestNewDefaultRegistryHasAllProviders(t *testing.T) {
+	r := NewDefaultRegistry()
+	for _, name := range []string{"github", "gitlab", "jenkins", "argocd", "pagerduty", "prometheus"} {
+		if _, err := r.Get(name); err != nil {
+			t.Errorf("expected provider %q to be registered: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// FileConfig is the subset of collector configuration that can be changed
+// at runtime via a SIGHUP-triggered reload, without restarting the pod.
+type FileConfig struct {
+	// WebhookURL is the default webhook sink URL for DORAMetrics whose
+	// spec.export.webhook doesn't set one of its own.
+	WebhookURL string `json:"webhookURL"`
+
+	// AuthTokens maps a provider name (see providers.Registry) to a bearer
+	// token, used as a fallback when a metric's source.authSecretRef is unset.
+	AuthTokens map[string]string `json:"authTokens"`
+
+	// SampleInterval is a Go duration string (e.g. "15m") used as the
+	// default collection window for metrics whose source.window is unset.
+	SampleInterval string `json:"sampleInterval"`
+}
+
+// ConfigStore holds the live FileConfig loaded from path, guarded so a
+// SIGHUP-triggered reload is safe to race against concurrent reads from
+// in-flight scrapes: readers get a snapshot via Get and finish their scrape
+// against it even if a reload swaps the config underneath them.
+type ConfigStore struct {
+	path string
+
+	mu  sync.RWMutex
+	cfg FileConfig
+}
+
+// NewConfigStore returns a ConfigStore backed by path. An empty path
+// disables file-based config entirely; Get then always returns the zero
+// FileConfig and WatchSIGHUP is a no-op.
+func NewConfigStore(path string) *ConfigStore {
+	return &ConfigStore{path: path}
+}
+
+// Load reads the config file once, populating the initial snapshot. It is a
+// no-op if no path was configured.
+func (s *ConfigStore) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	return s.reload()
+}
+
+// Get returns the current config snapshot.
+func (s *ConfigStore) Get() FileConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+func (s *ConfigStore) reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("reading config file %s: %w", s.path, err)
+	}
+
+	var cfg FileConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	s.cfg = cfg
+	s.mu.Unlock()
+	return nil
+}
+
+// WatchSIGHUP re-reads the config file on every SIGHUP, mirroring the
+// reload-on-SIGHUP pattern used by frostfs-node's pprof/metrics servers.
+// It blocks until ctx is done.
+func (s *ConfigStore) WatchSIGHUP(ctx context.Context) {
+	if s.path == "" {
+		return
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			if err := s.reload(); err != nil {
+				setupLog.Error(err, "failed to reload config on SIGHUP", "path", s.path)
+				continue
+			}
+			setupLog.Info("reloaded collector config on SIGHUP", "path", s.path)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+	"github.com/jefrnc/dora-operator/providers"
+)
+
+// defaultWindow is used when a MetricConfig.Source.Window is unset.
+const defaultWindow = 24 * time.Hour
+
+// metricConfigsEqual reports whether two MetricConfigs are equivalent for
+// scheduling purposes. It compares AuthSecretRef by name rather than by
+// pointer, since a and b are typically decoded from separate API reads of
+// the same DORAMetric and so never share pointers even when unchanged.
+func metricConfigsEqual(a, b metricsv1.MetricConfig) bool {
+	if a.Name != b.Name || a.Enabled != b.Enabled || a.Schedule != b.Schedule {
+		return false
+	}
+	as, bs := a.Source, b.Source
+	as.AuthSecretRef, bs.AuthSecretRef = nil, nil
+	if as != bs {
+		return false
+	}
+	aRef, bRef := a.Source.AuthSecretRef, b.Source.AuthSecretRef
+	if (aRef == nil) != (bRef == nil) {
+		return false
+	}
+	return aRef == nil || aRef.Name == bRef.Name
+}
+
+// buildWindow turns a SourceConfig's Window string into a providers.Window
+// ending now, falling back to the live config's SampleInterval and then to
+// defaultWindow when the CR doesn't set one.
+func buildWindow(source metricsv1.SourceConfig, fallback FileConfig) (providers.Window, error) {
+	windowStr := source.Window
+	if windowStr == "" {
+		windowStr = fallback.SampleInterval
+	}
+
+	d := defaultWindow
+	if windowStr != "" {
+		parsed, err := time.ParseDuration(windowStr)
+		if err != nil {
+			return providers.Window{}, fmt.Errorf("invalid window %q: %w", windowStr, err)
+		}
+		d = parsed
+	}
+	until := time.Now()
+	return providers.Window{Since: until.Add(-d), Until: until}, nil
+}
+
+// resolveFilters builds a providers.Filters from a MetricConfig's source
+// config, resolving the auth token from the referenced Secret if one is
+// set, or from the live file-based config as a fallback otherwise.
+func (mc *MetricsCollector) resolveFilters(ctx context.Context, dm *metricsv1.DORAMetric, source metricsv1.SourceConfig) (providers.Filters, error) {
+	filters := providers.Filters{
+		Repo:      source.Repo,
+		Project:   source.Project,
+		Namespace: source.Namespace,
+		Service:   source.Service,
+		Endpoint:  source.Endpoint,
+	}
+
+	if source.AuthSecretRef != nil && source.AuthSecretRef.Name != "" {
+		var secret corev1.Secret
+		key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: source.AuthSecretRef.Name}
+		if err := mc.Client.Get(ctx, key, &secret); err != nil {
+			return providers.Filters{}, fmt.Errorf("resolving auth secret %s: %w", key, err)
+		}
+		token, ok := secret.Data["token"]
+		if !ok {
+			return providers.Filters{}, fmt.Errorf("secret %s has no %q key", key, "token")
+		}
+		filters.AuthToken = string(token)
+	} else if mc.Config != nil {
+		filters.AuthToken = mc.Config.Get().AuthTokens[source.Provider]
+	}
+
+	return filters, nil
+}
+
+// collectFromProvider resolves the provider and filters for a MetricConfig
+// and invokes fn, the provider method corresponding to the metric being
+// collected.
+func (mc *MetricsCollector) collectFromProvider(
+	ctx context.Context,
+	dm *metricsv1.DORAMetric,
+	metric metricsv1.MetricConfig,
+	fn func(p providers.Provider, ctx context.Context, window providers.Window, filters providers.Filters) (float64, error),
+) (float64, error) {
+	if metric.Source.Provider == "" {
+		return 0, fmt.Errorf("metric %s has no source.provider configured", metric.Name)
+	}
+
+	provider, err := mc.Providers.Get(metric.Source.Provider)
+	if err != nil {
+		return 0, err
+	}
+
+	var fallback FileConfig
+	if mc.Config != nil {
+		fallback = mc.Config.Get()
+	}
+	window, err := buildWindow(metric.Source, fallback)
+	if err != nil {
+		return 0, err
+	}
+
+	filters, err := mc.resolveFilters(ctx, dm, metric.Source)
+	if err != nil {
+		return 0, err
+	}
+
+	return fn(provider, ctx, window, filters)
+}
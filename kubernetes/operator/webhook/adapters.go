@@ -0,0 +1,46 @@
+package webhook
+
+import "fmt"
+
+// slackPayload is the minimal shape Slack's incoming webhooks accept.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func toSlackPayload(ev Event) slackPayload {
+	return slackPayload{
+		Text: fmt.Sprintf("*%s/%s* — %s = %.2f (window: %s)",
+			ev.DORAMetricNamespace, ev.DORAMetricName, ev.MetricName, ev.Value, ev.Window),
+	}
+}
+
+// pagerdutyEventV2 is a trigger event for PagerDuty's Events API v2.
+// See https://developer.pagerduty.com/api-reference/.
+type pagerdutyEventV2 struct {
+	RoutingKey  string                  `json:"routing_key"`
+	EventAction string                  `json:"event_action"`
+	Payload     pagerdutyEventV2Payload `json:"payload"`
+}
+
+type pagerdutyEventV2Payload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+func toPagerDutyPayload(ev Event, routingKey string) pagerdutyEventV2 {
+	return pagerdutyEventV2{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		Payload: pagerdutyEventV2Payload{
+			Summary:  fmt.Sprintf("%s/%s: %s = %.2f", ev.DORAMetricNamespace, ev.DORAMetricName, ev.MetricName, ev.Value),
+			Source:   "dora-operator/" + ev.DORAMetricNamespace + "/" + ev.DORAMetricName,
+			Severity: "warning",
+			CustomDetails: map[string]interface{}{
+				"window": ev.Window,
+				"labels": ev.Labels,
+			},
+		},
+	}
+}
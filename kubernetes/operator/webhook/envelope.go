@@ -0,0 +1,57 @@
+// Package webhook sends collected DORA metric values to outbound sinks:
+// a generic HMAC-signed CloudEvents 1.0 envelope, a Slack incoming
+// webhook, or a PagerDuty Events v2 trigger.
+package webhook
+
+import "time"
+
+// Event is a single collected metric value, ready to be rendered for any
+// sink type.
+type Event struct {
+	DORAMetricName      string
+	DORAMetricNamespace string
+	MetricName          string
+	Value               float64
+	Window              string
+	Labels              map[string]string
+	Time                time.Time
+}
+
+// CloudEvent is a CloudEvents 1.0 JSON envelope carrying an Event as its
+// data payload. See https://github.com/cloudevents/spec.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	Type            string    `json:"type"`
+	Source          string    `json:"source"`
+	ID              string    `json:"id"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            EventData `json:"data"`
+}
+
+// EventData is the CloudEvent's data payload.
+type EventData struct {
+	Metric string            `json:"metric"`
+	Value  float64           `json:"value"`
+	Window string            `json:"window"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// toCloudEvent renders ev as a CloudEvents 1.0 envelope. id is typically a
+// UUID or similar caller-supplied identifier.
+func toCloudEvent(ev Event, id string) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		Type:            "com.dora.metric.collected",
+		Source:          "dora-operator/" + ev.DORAMetricNamespace + "/" + ev.DORAMetricName,
+		ID:              id,
+		Time:            ev.Time,
+		DataContentType: "application/json",
+		Data: EventData{
+			Metric: ev.MetricName,
+			Value:  ev.Value,
+			Window: ev.Window,
+			Labels: ev.Labels,
+		},
+	}
+}
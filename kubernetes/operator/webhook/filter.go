@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MatchFilter evaluates a small filter DSL of the form "<metric> <op>
+// <threshold>", e.g. "mttr > 60", against ev. A metric named in the filter
+// that doesn't match ev.MetricName always passes (the filter simply doesn't
+// apply to that event). An empty filter always passes.
+func MatchFilter(filter string, ev Event) (bool, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return true, nil
+	}
+
+	fields := strings.Fields(filter)
+	if len(fields) != 3 {
+		return false, fmt.Errorf("webhook: invalid filter %q: want \"<metric> <op> <threshold>\"", filter)
+	}
+	metric, op, thresholdStr := fields[0], fields[1], fields[2]
+
+	if metric != ev.MetricName {
+		return true, nil
+	}
+
+	threshold, err := strconv.ParseFloat(thresholdStr, 64)
+	if err != nil {
+		return false, fmt.Errorf("webhook: invalid filter %q: threshold %q is not a number", filter, thresholdStr)
+	}
+
+	switch op {
+	case ">":
+		return ev.Value > threshold, nil
+	case ">=":
+		return ev.Value >= threshold, nil
+	case "<":
+		return ev.Value < threshold, nil
+	case "<=":
+		return ev.Value <= threshold, nil
+	case "==":
+		return ev.Value == threshold, nil
+	case "!=":
+		return ev.Value != threshold, nil
+	default:
+		return false, fmt.Errorf("webhook: invalid filter %q: unknown operator %q", filter, op)
+	}
+}
@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// sign returns the hex-encoded HMAC-SHA256 of body using secret, in the
+// "sha256=<hex>" form GitHub/Stripe-style webhook consumers expect.
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
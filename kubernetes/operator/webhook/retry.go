@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doWithRetry spaces out repeated delivery
+// attempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is used when a sink doesn't need a custom policy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// doWithRetry calls send up to policy.MaxAttempts times, backing off
+// exponentially with full jitter between attempts. If a non-2xx response
+// carries a Retry-After header, that delay is honored instead of the
+// computed backoff. It gives up early if ctx is done.
+func doWithRetry(ctx context.Context, policy RetryPolicy, send func() (*http.Response, error)) error {
+	var lastErr error
+
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := backoffDelay(policy, attempt, lastErr)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		resp, err := send()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = retryableStatusError{statusCode: resp.StatusCode, retryAfter: retryAfterDelay(resp)}
+	}
+
+	return fmt.Errorf("webhook: delivery failed after %d attempts: %w", policy.MaxAttempts, lastErr)
+}
+
+type retryableStatusError struct {
+	statusCode int
+	retryAfter time.Duration
+}
+
+func (e retryableStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.statusCode)
+}
+
+func backoffDelay(policy RetryPolicy, attempt int, lastErr error) time.Duration {
+	if statusErr, ok := lastErr.(retryableStatusError); ok && statusErr.retryAfter > 0 {
+		return statusErr.retryAfter
+	}
+
+	delay := policy.BaseDelay * time.Duration(1<<uint(attempt-1))
+	if delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	// Full jitter: a uniform random delay in [0, delay].
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// retryAfterDelay parses a Retry-After header as either a number of
+// seconds or an HTTP-date, returning 0 if absent or unparseable.
+func retryAfterDelay(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Sink is one configured webhook destination. Secret is the raw value
+// resolved from the CR's secretRef - an HMAC signing key for "generic"
+// sinks, or a PagerDuty routing key for "pagerduty" sinks. It is nil for
+// sinks that don't need one (e.g. "slack").
+type Sink struct {
+	Name   string
+	Type   string // "generic", "slack" or "pagerduty"
+	URL    string
+	Secret []byte
+	Filter string
+}
+
+// Sender delivers Events to Sinks, applying each sink's filter and
+// retrying failed deliveries.
+type Sender struct {
+	HTTPClient  *http.Client
+	RetryPolicy RetryPolicy
+
+	// NewEventID returns a unique ID for each CloudEvent. Defaults to a
+	// random UUID if unset; tests can inject a deterministic one.
+	NewEventID func() string
+}
+
+func (s *Sender) newEventID() string {
+	if s.NewEventID != nil {
+		return s.NewEventID()
+	}
+	return uuid.NewString()
+}
+
+func (s *Sender) httpClient() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (s *Sender) retryPolicy() RetryPolicy {
+	if s.RetryPolicy == (RetryPolicy{}) {
+		return DefaultRetryPolicy
+	}
+	return s.RetryPolicy
+}
+
+// Send renders ev for sink.Type, signs it if it is a generic sink (Type
+// "generic" or empty) and sink.Secret is set, and POSTs
+// it to sink.URL with retries. It returns a non-nil error if the filter
+// rejected the event (ErrFiltered) or delivery ultimately failed.
+func (s *Sender) Send(ctx context.Context, sink Sink, ev Event) error {
+	pass, err := MatchFilter(sink.Filter, ev)
+	if err != nil {
+		return err
+	}
+	if !pass {
+		return ErrFiltered
+	}
+
+	body, err := s.render(sink, ev)
+	if err != nil {
+		return fmt.Errorf("webhook: rendering payload for sink %s: %w", sink.Name, err)
+	}
+
+	return doWithRetry(ctx, s.retryPolicy(), func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if (sink.Type == "" || sink.Type == "generic") && len(sink.Secret) > 0 {
+			req.Header.Set("X-Dora-Signature", sign(sink.Secret, body))
+		}
+		return s.httpClient().Do(req)
+	})
+}
+
+// ErrFiltered is returned by Send when a sink's filter rejected the event;
+// callers should treat it as "skipped", not a delivery failure.
+var ErrFiltered = fmt.Errorf("webhook: event did not match sink filter")
+
+func (s *Sender) render(sink Sink, ev Event) ([]byte, error) {
+	switch sink.Type {
+	case "", "generic":
+		return json.Marshal(toCloudEvent(ev, s.newEventID()))
+	case "slack":
+		return json.Marshal(toSlackPayload(ev))
+	case "pagerduty":
+		return json.Marshal(toPagerDutyPayload(ev, string(sink.Secret)))
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sink.Type)
+	}
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMatchFilter(t *testing.T) {
+	ev := Event{MetricName: "mttr", Value: 90}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"mttr > 60", true},
+		{"mttr > 120", false},
+		{"lead-time > 1", true}, // different metric: filter doesn't apply
+	}
+	for _, tt := range tests {
+		got, err := MatchFilter(tt.filter, ev)
+		if err != nil {
+			t.Fatalf("MatchFilter(%q): unexpected error: %v", tt.filter, err)
+		}
+		if got != tt.want {
+			t.Errorf("MatchFilter(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestMatchFilterInvalid(t *testing.T) {
+	if _, err := MatchFilter("mttr >>", Event{MetricName: "mttr"}); err == nil {
+		t.Fatal("expected error for malformed filter")
+	}
+}
+
+func TestSenderSendGenericSignsBody(t *testing.T) {
+	var gotSignature string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Dora-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := &Sender{HTTPClient: srv.Client(), NewEventID: func() string { return "fixed-id" }}
+	sink := Sink{Name: "default", Type: "generic", URL: srv.URL, Secret: []byte("s3cr3t")}
+	ev := Event{DORAMetricName: "checkout", DORAMetricNamespace: "prod", MetricName: "mttr", Value: 42, Window: "24h", Time: time.Unix(0, 0).UTC()}
+
+	if err := sender.Send(context.Background(), sink, ev); err != nil {
+		t.Fatalf("Send: unexpected error: %v", err)
+	}
+	if gotSignature == "" {
+		t.Fatal("expected X-Dora-Signature header to be set")
+	}
+	if want := sign(sink.Secret, gotBody); gotSignature != want {
+		t.Errorf("signature mismatch: got %s, want %s", gotSignature, want)
+	}
+
+	var decoded CloudEvent
+	if err := json.Unmarshal(gotBody, &decoded); err != nil {
+		t.Fatalf("unmarshaling body: %v", err)
+	}
+	if decoded.Type != "com.dora.metric.collected" {
+		t.Errorf("Type = %q, want com.dora.metric.collected", decoded.Type)
+	}
+	if decoded.Data.Metric != "mttr" || decoded.Data.Value != 42 {
+		t.Errorf("Data = %+v, want metric=mttr value=42", decoded.Data)
+	}
+}
+
+func TestSenderSendFiltered(t *testing.T) {
+	sender := &Sender{}
+	sink := Sink{Name: "quiet", Type: "generic", URL: "http://unused.invalid", Filter: "mttr > 1000"}
+	ev := Event{MetricName: "mttr", Value: 10}
+
+	if err := sender.Send(context.Background(), sink, ev); err != ErrFiltered {
+		t.Errorf("Send error = %v, want ErrFiltered", err)
+	}
+}
+
+func TestDoWithRetryHonorsRetryAfter(t *testing.T) {
+	var calls int
+	start := time.Now()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	err := doWithRetry(context.Background(), RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}, func() (*http.Response, error) {
+		return srv.Client().Post(srv.URL, "application/json", nil)
+	})
+	if err != nil {
+		t.Fatalf("doWithRetry: unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if time.Since(start) < time.Second {
+		t.Errorf("retry came after %v, before the 1s Retry-After", time.Since(start))
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("retry took too long: %v", time.Since(start))
+	}
+}
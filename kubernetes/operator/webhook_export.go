@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+
+	metricsv1 "github.com/jefrnc/dora-operator/api/v1"
+	"github.com/jefrnc/dora-operator/webhook"
+)
+
+// webhookSender is package-level so every MetricsCollector shares one
+// retrying HTTP client instead of allocating one per call.
+var webhookSender = &webhook.Sender{}
+
+// sendWebhook delivers metricName's value to every configured sink on dm,
+// recording the outcome as both a Prometheus counter and a status condition.
+func (mc *MetricsCollector) sendWebhook(dm *metricsv1.DORAMetric, metricName string, value float64) error {
+	ctx := context.Background()
+
+	ev := webhook.Event{
+		DORAMetricName:      dm.Name,
+		DORAMetricNamespace: dm.Namespace,
+		MetricName:          metricName,
+		Value:               value,
+		Window:              "", // per-metric window isn't threaded through here; sinks see the raw value
+		Labels: map[string]string{
+			"team":        dm.Spec.Team,
+			"service":     dm.Spec.Service,
+			"environment": dm.Spec.Environment,
+		},
+		Time: metav1.Now().Time,
+	}
+
+	sinks := dm.Spec.Export.Webhook.Sinks
+	if len(sinks) == 0 && mc.Config != nil {
+		if url := mc.Config.Get().WebhookURL; url != "" {
+			sinks = []metricsv1.WebhookSink{{Name: "default", Type: "generic", URL: url}}
+		}
+	}
+
+	var errs []error
+	for _, sinkSpec := range sinks {
+		sink, err := mc.resolveSink(ctx, dm, sinkSpec)
+		if err != nil {
+			errs = append(errs, err)
+			mc.recordWebhookOutcome(dm, sinkSpec.Name, "failed")
+			mc.setWebhookCondition(dm, sinkSpec.Name, metav1.ConditionFalse, "SecretResolutionFailed", err.Error())
+			continue
+		}
+
+		switch err := webhookSender.Send(ctx, sink, ev); {
+		case err == nil:
+			mc.recordWebhookOutcome(dm, sinkSpec.Name, "delivered")
+			mc.setWebhookCondition(dm, sinkSpec.Name, metav1.ConditionTrue, "Delivered", "event delivered")
+		case errors.Is(err, webhook.ErrFiltered):
+			mc.recordWebhookOutcome(dm, sinkSpec.Name, "filtered")
+			mc.setWebhookCondition(dm, sinkSpec.Name, metav1.ConditionTrue, "Filtered", "event did not match sink filter")
+		default:
+			errs = append(errs, fmt.Errorf("sink %s: %w", sinkSpec.Name, err))
+			mc.recordWebhookOutcome(dm, sinkSpec.Name, "failed")
+			mc.setWebhookCondition(dm, sinkSpec.Name, metav1.ConditionFalse, "DeliveryFailed", err.Error())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (mc *MetricsCollector) resolveSink(ctx context.Context, dm *metricsv1.DORAMetric, spec metricsv1.WebhookSink) (webhook.Sink, error) {
+	sink := webhook.Sink{
+		Name:   spec.Name,
+		Type:   spec.Type,
+		URL:    spec.URL,
+		Filter: spec.Filter,
+	}
+
+	if spec.SecretRef != nil && spec.SecretRef.Name != "" {
+		var secret corev1.Secret
+		key := apitypes.NamespacedName{Namespace: dm.Namespace, Name: spec.SecretRef.Name}
+		if err := mc.Client.Get(ctx, key, &secret); err != nil {
+			return webhook.Sink{}, fmt.Errorf("resolving secret %s: %w", key, err)
+		}
+		value, ok := secret.Data["secret"]
+		if !ok {
+			return webhook.Sink{}, fmt.Errorf("secret %s has no %q key", key, "secret")
+		}
+		sink.Secret = value
+	}
+
+	return sink, nil
+}
+
+func (mc *MetricsCollector) recordWebhookOutcome(dm *metricsv1.DORAMetric, sinkName, outcome string) {
+	doraWebhookDeliveriesTotal.WithLabelValues(dm.Name, dm.Namespace, sinkName, outcome).Inc()
+}
+
+// setWebhookCondition mirrors the outcome of a sink's latest delivery
+// attempt onto dm.Status.Conditions, so `kubectl describe` shows it without
+// needing to scrape Prometheus.
+func (mc *MetricsCollector) setWebhookCondition(dm *metricsv1.DORAMetric, sinkName string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&dm.Status.Conditions, metav1.Condition{
+		Type:               "WebhookDelivery:" + sinkName,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: dm.Generation,
+	})
+}